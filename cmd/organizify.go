@@ -2,83 +2,113 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ExploHash/organizify/pkg/organizer"
 	"github.com/ExploHash/organizify/pkg/spotify"
 )
 
 func main() {
-	ctx := context.Background()
+	rulesPath := flag.String("rules", "", "path to a YAML auto-organizer rule file; runs the organizer headlessly instead of launching the TUI, applying its changes unless -dry-run is set")
+	dryRun := flag.Bool("dry-run", false, "with -rules, print the intended playlist diff without mutating the account, and skip the TUI")
+	exportPath := flag.String("export", "", "write every playlist and liked song to a JSON snapshot at this path, then exit")
+	importPath := flag.String("import", "", "restore a JSON snapshot written by -export into this account, then exit")
+	m3uDir := flag.String("m3u", "", "write every playlist and liked songs as M3U files into this directory, then exit")
+	flag.Parse()
 
-	fmt.Println("=== Organizify - Spotify Authentication Test ===\n")
+	ctx := context.Background()
 
-	// Test authentication and create client
 	fmt.Println("Authenticating with Spotify...")
-	spotifyClient, err := spotify.NewClient(ctx)
+	client, err := spotify.NewClient(ctx)
 	if err != nil {
 		log.Fatalf("Failed to authenticate: %v", err)
 	}
 
-	// Get current user
-	user, err := spotifyClient.GetCurrentUser()
+	user, err := client.GetCurrentUser()
 	if err != nil {
 		log.Fatalf("Failed to get user: %v", err)
 	}
-	fmt.Printf("\n✓ Logged in as: %s\n", user.DisplayName)
 
-	// Get playlists count
-	playlistCount, err := spotifyClient.GetPlaylistsCount()
-	if err != nil {
-		log.Fatalf("Failed to get playlists count: %v", err)
+	if *exportPath != "" {
+		if err := runExport(client, *exportPath); err != nil {
+			log.Fatalf("Export failed: %v", err)
+		}
+		return
+	}
+
+	if *importPath != "" {
+		if err := runImport(client, user.ID, *importPath); err != nil {
+			log.Fatalf("Import failed: %v", err)
+		}
+		return
+	}
+
+	if *m3uDir != "" {
+		if err := runM3UExport(client, *m3uDir); err != nil {
+			log.Fatalf("M3U export failed: %v", err)
+		}
+		return
 	}
-	fmt.Printf("✓ Total playlists: %d\n", playlistCount)
 
-	// Get liked songs count
-	likedCount, err := spotifyClient.GetLikedSongsCount()
+	if *rulesPath != "" {
+		if err := runOrganizer(client, user.ID, *rulesPath, *dryRun); err != nil {
+			log.Fatalf("Organizer failed: %v", err)
+		}
+		return
+	}
+
+	program := tea.NewProgram(newModel(client, user.ID, *rulesPath), tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		log.Fatalf("TUI exited with error: %v", err)
+	}
+}
+
+// runOrganizer classifies the user's liked songs per the rules at
+// rulesPath, plans the resulting playlist diffs, prints them, and applies
+// them unless dryRun is set.
+func runOrganizer(c *spotify.Client, userID, rulesPath string, dryRun bool) error {
+	rules, err := organizer.LoadRules(rulesPath)
 	if err != nil {
-		log.Fatalf("Failed to get liked songs count: %v", err)
+		return err
 	}
-	fmt.Printf("✓ Total liked songs: %d\n", likedCount)
 
-	// Fetch all playlists
-	fmt.Println("\nFetching all playlists...")
-	playlists, err := spotifyClient.GetAllPlaylists()
+	liked, err := c.GetLikedSongs()
 	if err != nil {
-		log.Fatalf("Failed to get playlists: %v", err)
+		return fmt.Errorf("failed to get liked songs: %w", err)
 	}
 
-	fmt.Printf("\nYour Playlists (%d total):\n", len(playlists))
-	for i, playlist := range playlists {
-		if i >= 10 {
-			fmt.Printf("... and %d more\n", len(playlists)-10)
-			break
-		}
-		fmt.Printf("  %d. %s - %d tracks\n", i+1, playlist.Name, playlist.Tracks.Total)
+	assignments, err := organizer.Classify(c, rules, liked)
+	if err != nil {
+		return err
 	}
 
-	// Fetch first 10 liked songs
-	fmt.Println("\nFetching liked songs (first 10)...")
-	likedSongs, err := spotifyClient.GetLikedSongs()
+	diffs, err := organizer.Plan(c, assignments)
 	if err != nil {
-		log.Fatalf("Failed to get liked songs: %v", err)
+		return err
 	}
 
-	fmt.Printf("\nYour Liked Songs (showing 10 of %d):\n", len(likedSongs))
-	for i, item := range likedSongs {
-		if i >= 10 {
-			break
+	for _, diff := range diffs {
+		fmt.Printf("\nPlaylist %q:\n", diff.Playlist)
+		for _, t := range diff.ToAdd {
+			fmt.Printf("  + %s\n", t.Name)
 		}
-		track := item.Track
-		artists := ""
-		for j, artist := range track.Artists {
-			if j > 0 {
-				artists += ", "
-			}
-			artists += artist.Name
+		for _, t := range diff.ToRemove {
+			fmt.Printf("  - %s\n", t.Name)
 		}
-		fmt.Printf("  %d. %s - %s\n", i+1, track.Name, artists)
 	}
 
-	fmt.Println("\n✓ Test completed successfully!")
+	if dryRun {
+		fmt.Println("\n(dry run: no changes applied)")
+		return nil
+	}
+
+	if err := organizer.Apply(c, userID, diffs); err != nil {
+		return err
+	}
+	fmt.Println("\n✓ Organizer changes applied!")
+	return nil
 }
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ExploHash/organizify/pkg/spotify"
+	"github.com/ExploHash/organizify/pkg/spotify/portable"
+)
+
+// runExport captures every playlist and liked song into a JSON snapshot
+// and writes it to path.
+func runExport(c *spotify.Client, path string) error {
+	snap, err := portable.Capture(c)
+	if err != nil {
+		return err
+	}
+	if err := portable.Save(snap, path); err != nil {
+		return err
+	}
+	fmt.Printf("\n✓ Wrote snapshot to %s (%d playlists, %d liked songs)\n", path, len(snap.Playlists), len(snap.LikedSongs))
+	return nil
+}
+
+// runImport restores a JSON snapshot at path into the account behind c.
+// Tracks that couldn't be confidently matched are logged to a report file
+// next to path rather than aborting the restore.
+func runImport(c *spotify.Client, userID, path string) error {
+	snap, err := portable.Load(path)
+	if err != nil {
+		return err
+	}
+
+	unresolved, err := portable.Restore(c, userID, snap)
+	if err != nil {
+		return err
+	}
+
+	if len(unresolved) > 0 {
+		reportPath := path + ".unresolved.txt"
+		if err := portable.WriteUnresolvedReport(unresolved, reportPath); err != nil {
+			return err
+		}
+		fmt.Printf("\n%d track(s) couldn't be resolved; see %s\n", len(unresolved), reportPath)
+	}
+
+	fmt.Println("\n✓ Snapshot restored!")
+	return nil
+}
+
+// runM3UExport writes every playlist, plus liked songs, as an M3U file
+// into dir, one file per playlist.
+func runM3UExport(c *spotify.Client, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	playlists, err := c.GetAllPlaylists()
+	if err != nil {
+		return fmt.Errorf("failed to get playlists: %w", err)
+	}
+
+	for _, playlist := range playlists {
+		tracks, err := c.GetPlaylistTracks(playlist.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get tracks for playlist %q: %w", playlist.Name, err)
+		}
+		if err := portable.WriteM3U(tracks, filepath.Join(dir, m3uFilename(playlist.Name))); err != nil {
+			return err
+		}
+	}
+
+	liked, err := c.GetLikedSongs()
+	if err != nil {
+		return fmt.Errorf("failed to get liked songs: %w", err)
+	}
+	likedTracks := make([]spotify.Track, len(liked))
+	for i, s := range liked {
+		likedTracks[i] = s.Track
+	}
+	if err := portable.WriteM3U(likedTracks, filepath.Join(dir, m3uFilename("Liked Songs"))); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n✓ Wrote %d M3U file(s) to %s\n", len(playlists)+1, dir)
+	return nil
+}
+
+// m3uFilename turns a playlist name into a safe file name, replacing path
+// separators that would otherwise escape dir or fail outright.
+func m3uFilename(name string) string {
+	safe := strings.NewReplacer("/", "-", "\\", "-").Replace(name)
+	return safe + ".m3u"
+}
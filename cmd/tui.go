@@ -0,0 +1,529 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ExploHash/organizify/pkg/organizer"
+	"github.com/ExploHash/organizify/pkg/spotify"
+)
+
+// pane identifies which list currently has keyboard focus.
+type pane int
+
+const (
+	playlistsPane pane = iota
+	tracksPane
+)
+
+// pendingAction tracks an in-progress move/copy: the user picked a
+// source track, then needs to pick a destination playlist before the
+// write actually happens.
+type pendingAction int
+
+const (
+	actionNone pendingAction = iota
+	actionMove
+	actionCopy
+)
+
+// playlistItem adapts spotify.Playlist to list.Item.
+type playlistItem struct{ playlist spotify.Playlist }
+
+func (p playlistItem) Title() string       { return p.playlist.Name }
+func (p playlistItem) Description() string { return fmt.Sprintf("%d tracks", p.playlist.Tracks.Total) }
+func (p playlistItem) FilterValue() string { return p.playlist.Name }
+
+// trackItem adapts spotify.Track to list.Item, tracking whether it's
+// among the user's liked songs so the list can mark it.
+type trackItem struct {
+	track spotify.Track
+	liked bool
+}
+
+func (t trackItem) Title() string {
+	if t.liked {
+		return "♥ " + t.track.Name
+	}
+	return t.track.Name
+}
+func (t trackItem) Description() string { return artistNames(t.track) }
+func (t trackItem) FilterValue() string { return t.track.Name }
+
+func artistNames(track spotify.Track) string {
+	names := make([]string, len(track.Artists))
+	for i, artist := range track.Artists {
+		names[i] = artist.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// model is the TUI's Bubble Tea model. It's a thin consumer of
+// spotify.Client: every mutation goes through the same API a headless
+// script would use (see runOrganizer).
+type model struct {
+	client    *spotify.Client
+	userID    string
+	rulesPath string
+
+	playlists list.Model
+	tracks    list.Model
+	spinner   spinner.Model
+
+	focus         pane
+	loadingTracks bool
+	likedIDs      map[string]bool
+
+	pending          pendingAction
+	pendingTrack     *spotify.Track
+	sourcePlaylistID string
+
+	diffs    []organizer.PlaylistDiff
+	showDiff bool
+
+	status string
+	err    error
+
+	width, height int
+}
+
+// newModel creates the TUI model for client. rulesPath, if non-empty,
+// enables the "d" diff view against that rule file.
+func newModel(client *spotify.Client, userID, rulesPath string) model {
+	playlists := list.New(nil, list.NewDefaultDelegate(), 0, 0)
+	playlists.Title = "Playlists"
+
+	tracks := list.New(nil, list.NewDefaultDelegate(), 0, 0)
+	tracks.Title = "Tracks"
+
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+
+	return model{
+		client:    client,
+		userID:    userID,
+		rulesPath: rulesPath,
+		playlists: playlists,
+		tracks:    tracks,
+		spinner:   s,
+		focus:     playlistsPane,
+		likedIDs:  make(map[string]bool),
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return tea.Batch(loadPlaylistsCmd(m.client), loadLikedCmd(m.client), m.spinner.Tick)
+}
+
+// --- messages & commands -------------------------------------------------
+
+type playlistsLoadedMsg struct {
+	playlists []spotify.Playlist
+	err       error
+}
+
+type tracksLoadedMsg struct {
+	tracks []spotify.Track
+	err    error
+}
+
+type likedLoadedMsg struct {
+	liked map[string]bool
+	err   error
+}
+
+type diffLoadedMsg struct {
+	diffs []organizer.PlaylistDiff
+	err   error
+}
+
+type actionDoneMsg struct {
+	status string
+	err    error
+}
+
+func loadPlaylistsCmd(c *spotify.Client) tea.Cmd {
+	return func() tea.Msg {
+		playlists, err := c.GetAllPlaylists()
+		return playlistsLoadedMsg{playlists: playlists, err: err}
+	}
+}
+
+func loadTracksCmd(c *spotify.Client, playlistID string) tea.Cmd {
+	return func() tea.Msg {
+		tracks, err := c.GetPlaylistTracks(playlistID)
+		return tracksLoadedMsg{tracks: tracks, err: err}
+	}
+}
+
+func loadLikedCmd(c *spotify.Client) tea.Cmd {
+	return func() tea.Msg {
+		saved, err := c.GetLikedSongs()
+		if err != nil {
+			return likedLoadedMsg{err: err}
+		}
+		liked := make(map[string]bool, len(saved))
+		for _, s := range saved {
+			liked[s.Track.ID] = true
+		}
+		return likedLoadedMsg{liked: liked}
+	}
+}
+
+func loadDiffCmd(c *spotify.Client, rulesPath string) tea.Cmd {
+	return func() tea.Msg {
+		rules, err := organizer.LoadRules(rulesPath)
+		if err != nil {
+			return diffLoadedMsg{err: err}
+		}
+
+		saved, err := c.GetLikedSongs()
+		if err != nil {
+			return diffLoadedMsg{err: err}
+		}
+
+		assignments, err := organizer.Classify(c, rules, saved)
+		if err != nil {
+			return diffLoadedMsg{err: err}
+		}
+
+		diffs, err := organizer.Plan(c, assignments)
+		return diffLoadedMsg{diffs: diffs, err: err}
+	}
+}
+
+func addTrackCmd(c *spotify.Client, playlistID string, track spotify.Track) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := c.AddTracksToPlaylist(playlistID, []string{"spotify:track:" + track.ID}); err != nil {
+			return actionDoneMsg{err: err}
+		}
+		return actionDoneMsg{status: fmt.Sprintf("added %q", track.Name)}
+	}
+}
+
+func removeTrackCmd(c *spotify.Client, playlistID string, track spotify.Track) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := c.RemoveTracksFromPlaylist(playlistID, []string{"spotify:track:" + track.ID}, spotify.PlaylistSnapshot{}); err != nil {
+			return actionDoneMsg{err: err}
+		}
+		return actionDoneMsg{status: fmt.Sprintf("removed %q", track.Name)}
+	}
+}
+
+func toggleLikedCmd(c *spotify.Client, track spotify.Track, liked bool) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		verb := "liked"
+		if liked {
+			verb = "unliked"
+			err = c.RemoveSavedTracks([]string{track.ID})
+		} else {
+			err = c.SaveTracks([]string{track.ID})
+		}
+		if err != nil {
+			return actionDoneMsg{err: err}
+		}
+		return actionDoneMsg{status: fmt.Sprintf("%s %q", verb, track.Name)}
+	}
+}
+
+func unfollowPlaylistCmd(c *spotify.Client, playlist spotify.Playlist) tea.Cmd {
+	return func() tea.Msg {
+		if err := c.UnfollowPlaylist(playlist.ID); err != nil {
+			return actionDoneMsg{err: err}
+		}
+		return actionDoneMsg{status: fmt.Sprintf("unfollowed %q", playlist.Name)}
+	}
+}
+
+// --- update ---------------------------------------------------------------
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		paneHeight := msg.Height - 4
+		paneWidth := msg.Width / 3
+		m.playlists.SetSize(paneWidth, paneHeight)
+		m.tracks.SetSize(paneWidth, paneHeight)
+		return m, nil
+
+	case playlistsLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		items := make([]list.Item, len(msg.playlists))
+		for i, p := range msg.playlists {
+			items[i] = playlistItem{playlist: p}
+		}
+		m.playlists.SetItems(items)
+		return m, nil
+
+	case likedLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		m.likedIDs = msg.liked
+		return m, nil
+
+	case tracksLoadedMsg:
+		m.loadingTracks = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		items := make([]list.Item, len(msg.tracks))
+		for i, t := range msg.tracks {
+			items[i] = trackItem{track: t, liked: m.likedIDs[t.ID]}
+		}
+		m.tracks.SetItems(items)
+		return m, nil
+
+	case diffLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		m.diffs = msg.diffs
+		m.showDiff = true
+		return m, nil
+
+	case actionDoneMsg:
+		m.err = msg.err
+		if msg.err == nil {
+			m.status = msg.status
+		}
+		return m, m.refreshCmd()
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+// refreshCmd reloads the playlist list, liked songs, and the currently
+// selected playlist's tracks, used after a write so the panes reflect
+// what just happened.
+func (m model) refreshCmd() tea.Cmd {
+	cmds := []tea.Cmd{loadPlaylistsCmd(m.client), loadLikedCmd(m.client)}
+	if item, ok := m.playlists.SelectedItem().(playlistItem); ok {
+		cmds = append(cmds, loadTracksCmd(m.client, item.playlist.ID))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Let an active filter input on the focused list consume keys first.
+	if m.focus == playlistsPane && m.playlists.FilterState() == list.Filtering {
+		var cmd tea.Cmd
+		m.playlists, cmd = m.playlists.Update(msg)
+		return m, cmd
+	}
+	if m.focus == tracksPane && m.tracks.FilterState() == list.Filtering {
+		var cmd tea.Cmd
+		m.tracks, cmd = m.tracks.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "q":
+		if m.err != nil {
+			m.err = nil
+			return m, nil
+		}
+		if m.showDiff {
+			m.showDiff = false
+			return m, nil
+		}
+		return m, tea.Quit
+
+	case "tab":
+		if m.focus == playlistsPane {
+			m.focus = tracksPane
+		} else {
+			m.focus = playlistsPane
+		}
+		return m, nil
+
+	case "enter":
+		if m.pending != actionNone && m.focus == playlistsPane {
+			return m.resolvePendingAction()
+		}
+		if m.focus == playlistsPane {
+			if item, ok := m.playlists.SelectedItem().(playlistItem); ok {
+				m.loadingTracks = true
+				m.focus = tracksPane
+				return m, loadTracksCmd(m.client, item.playlist.ID)
+			}
+		}
+		return m, nil
+
+	case "d":
+		if m.rulesPath == "" {
+			m.status = "no -rules file configured"
+			return m, nil
+		}
+		return m, loadDiffCmd(m.client, m.rulesPath)
+
+	case "l":
+		if m.focus == tracksPane {
+			if item, ok := m.tracks.SelectedItem().(trackItem); ok {
+				return m, toggleLikedCmd(m.client, item.track, item.liked)
+			}
+		}
+		return m, nil
+
+	case "u":
+		if m.focus == playlistsPane {
+			if item, ok := m.playlists.SelectedItem().(playlistItem); ok {
+				return m, unfollowPlaylistCmd(m.client, item.playlist)
+			}
+		}
+		return m, nil
+
+	case "m", "c":
+		if m.focus != tracksPane || m.pending != actionNone {
+			return m, nil
+		}
+		item, ok := m.tracks.SelectedItem().(trackItem)
+		if !ok {
+			return m, nil
+		}
+		track := item.track
+		m.pendingTrack = &track
+		if msg.String() == "m" {
+			m.pending = actionMove
+		} else {
+			m.pending = actionCopy
+		}
+		if src, ok := m.playlists.SelectedItem().(playlistItem); ok {
+			m.sourcePlaylistID = src.playlist.ID
+		}
+		m.focus = playlistsPane
+		m.status = "select destination playlist, enter to confirm, esc to cancel"
+		return m, nil
+
+	case "esc":
+		if m.pending != actionNone {
+			m.pending = actionNone
+			m.pendingTrack = nil
+			m.status = "cancelled"
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	if m.focus == playlistsPane {
+		m.playlists, cmd = m.playlists.Update(msg)
+	} else {
+		m.tracks, cmd = m.tracks.Update(msg)
+	}
+	return m, cmd
+}
+
+// resolvePendingAction applies a pending move/copy against the playlist
+// currently selected in the playlists pane.
+func (m model) resolvePendingAction() (tea.Model, tea.Cmd) {
+	dest, ok := m.playlists.SelectedItem().(playlistItem)
+	if !ok || m.pendingTrack == nil {
+		m.pending = actionNone
+		return m, nil
+	}
+
+	track := *m.pendingTrack
+	action := m.pending
+	source := m.sourcePlaylistID
+
+	m.pending = actionNone
+	m.pendingTrack = nil
+	m.focus = tracksPane
+
+	cmds := []tea.Cmd{addTrackCmd(m.client, dest.playlist.ID, track)}
+	if action == actionMove && source != "" {
+		cmds = append(cmds, removeTrackCmd(m.client, source, track))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// --- view -------------------------------------------------------------
+
+func (m model) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("error: %v\n\npress q to dismiss, ctrl+c to quit", m.err)
+	}
+
+	if m.showDiff {
+		return m.diffView()
+	}
+
+	tracksView := m.tracks.View()
+	if m.loadingTracks {
+		tracksView = fmt.Sprintf("%s loading tracks...", m.spinner.View())
+	}
+
+	panes := lipgloss.JoinHorizontal(lipgloss.Top, m.playlists.View(), tracksView, m.detailView())
+
+	help := "tab: switch pane  /: filter  enter: open/confirm  m: move  c: copy  l: like  u: unfollow playlist  d: diff  q: quit"
+	if m.status != "" {
+		help = m.status + "  |  " + help
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, panes, help)
+}
+
+func (m model) detailView() string {
+	item, ok := m.tracks.SelectedItem().(trackItem)
+	if !ok {
+		return "no track selected"
+	}
+
+	t := item.track
+	lines := []string{
+		t.Name,
+		artistNames(t),
+		t.Album.Name,
+		fmt.Sprintf("%d:%02d", t.DurationMs/60000, (t.DurationMs/1000)%60),
+	}
+	if item.liked {
+		lines = append(lines, "♥ liked")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m model) diffView() string {
+	var b strings.Builder
+	b.WriteString("Pending organizer changes (press q to close)\n\n")
+	if len(m.diffs) == 0 {
+		b.WriteString("(no changes)\n")
+	}
+	for _, diff := range m.diffs {
+		fmt.Fprintf(&b, "%s:\n", diff.Playlist)
+		for _, t := range diff.ToAdd {
+			fmt.Fprintf(&b, "  + %s\n", t.Name)
+		}
+		for _, t := range diff.ToRemove {
+			fmt.Fprintf(&b, "  - %s\n", t.Name)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,187 @@
+package organizer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ExploHash/organizify/pkg/spotify"
+)
+
+// Assignment routes one liked song into one playlist. Classify emits one
+// Assignment per rule a song matches; a song that matches no rule gets
+// none.
+type Assignment struct {
+	Track    spotify.Track
+	Playlist string
+}
+
+// Classify evaluates set's rules, in order, against every saved track.
+// Audio features are only fetched (via Client.GetAudioFeatures) if some
+// rule actually needs them. A rule with StopOnMatch stops evaluation for
+// that track; without it, a track can match more than one rule and be
+// routed into more than one playlist.
+func Classify(c *spotify.Client, set *RuleSet, saved []spotify.SavedTrack) ([]Assignment, error) {
+	features, err := fetchNeededAudioFeatures(c, set, saved)
+	if err != nil {
+		return nil, err
+	}
+
+	var assignments []Assignment
+	for _, s := range saved {
+		addedAt, _ := time.Parse(time.RFC3339, s.AddedAt)
+
+		for _, rule := range set.Rules {
+			if !rule.Matches(s.Track, addedAt, features[s.Track.ID]) {
+				continue
+			}
+			assignments = append(assignments, Assignment{Track: s.Track, Playlist: rule.Playlist})
+			if rule.StopOnMatch {
+				break
+			}
+		}
+	}
+
+	return assignments, nil
+}
+
+func fetchNeededAudioFeatures(c *spotify.Client, set *RuleSet, saved []spotify.SavedTrack) (map[string]*spotify.AudioFeatures, error) {
+	needed := false
+	for _, rule := range set.Rules {
+		if rule.needsAudioFeatures() {
+			needed = true
+			break
+		}
+	}
+	if !needed {
+		return nil, nil
+	}
+
+	ids := make([]string, len(saved))
+	for i, s := range saved {
+		ids[i] = s.Track.ID
+	}
+
+	all, err := c.GetAudioFeatures(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audio features: %w", err)
+	}
+
+	byID := make(map[string]*spotify.AudioFeatures, len(all))
+	for i := range all {
+		byID[all[i].ID] = &all[i]
+	}
+	return byID, nil
+}
+
+// PlaylistDiff is the minimal set of add/remove operations needed to
+// make a playlist's contents match the tracks assigned to it.
+type PlaylistDiff struct {
+	Playlist   string
+	PlaylistID string // empty if the playlist doesn't exist yet
+	ToAdd      []spotify.Track
+	ToRemove   []spotify.Track
+}
+
+// Plan resolves each target playlist named in assignments via
+// GetPlaylistByName and diffs its current contents (via
+// GetPlaylistTracks) against the tracks assigned to it. A playlist that
+// doesn't exist yet gets a diff with an empty PlaylistID and everything
+// assigned to it in ToAdd. Lookup failures other than "playlist doesn't
+// exist" are propagated rather than treated as missing, so a transient
+// error (rate limiting, network, auth) can't result in a duplicate
+// playlist being created by Apply.
+func Plan(c *spotify.Client, assignments []Assignment) ([]PlaylistDiff, error) {
+	wanted := make(map[string][]spotify.Track)
+	var order []string
+	for _, a := range assignments {
+		if a.Playlist == "" {
+			continue
+		}
+		if _, seen := wanted[a.Playlist]; !seen {
+			order = append(order, a.Playlist)
+		}
+		wanted[a.Playlist] = append(wanted[a.Playlist], a.Track)
+	}
+
+	diffs := make([]PlaylistDiff, 0, len(order))
+	for _, name := range order {
+		tracks := wanted[name]
+
+		playlist, err := c.GetPlaylistByName(name)
+		if err != nil {
+			if !errors.Is(err, spotify.ErrPlaylistNotFound) {
+				return nil, fmt.Errorf("failed to look up playlist %q: %w", name, err)
+			}
+			diffs = append(diffs, PlaylistDiff{Playlist: name, ToAdd: tracks})
+			continue
+		}
+
+		current, err := c.GetPlaylistTracks(playlist.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tracks for playlist %q: %w", name, err)
+		}
+
+		diffs = append(diffs, PlaylistDiff{
+			Playlist:   name,
+			PlaylistID: playlist.ID,
+			ToAdd:      trackDifference(tracks, current),
+			ToRemove:   trackDifference(current, tracks),
+		})
+	}
+
+	return diffs, nil
+}
+
+// trackDifference returns the tracks in a whose ID doesn't appear in b.
+func trackDifference(a, b []spotify.Track) []spotify.Track {
+	inB := make(map[string]bool, len(b))
+	for _, t := range b {
+		inB[t.ID] = true
+	}
+
+	var diff []spotify.Track
+	for _, t := range a {
+		if !inB[t.ID] {
+			diff = append(diff, t)
+		}
+	}
+	return diff
+}
+
+// Apply issues the minimal AddTracksToPlaylist/RemoveTracksFromPlaylist
+// calls needed to make every playlist match its diff, creating playlists
+// that don't exist yet via CreatePlaylist.
+func Apply(c *spotify.Client, userID string, diffs []PlaylistDiff) error {
+	for _, diff := range diffs {
+		playlistID := diff.PlaylistID
+		if playlistID == "" {
+			playlist, err := c.CreatePlaylist(userID, diff.Playlist, "", false)
+			if err != nil {
+				return fmt.Errorf("failed to create playlist %q: %w", diff.Playlist, err)
+			}
+			playlistID = playlist.ID
+		}
+
+		if len(diff.ToAdd) > 0 {
+			if _, err := c.AddTracksToPlaylist(playlistID, trackURIs(diff.ToAdd)); err != nil {
+				return fmt.Errorf("failed to add tracks to playlist %q: %w", diff.Playlist, err)
+			}
+		}
+		if len(diff.ToRemove) > 0 {
+			if _, err := c.RemoveTracksFromPlaylist(playlistID, trackURIs(diff.ToRemove), spotify.PlaylistSnapshot{}); err != nil {
+				return fmt.Errorf("failed to remove tracks from playlist %q: %w", diff.Playlist, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func trackURIs(tracks []spotify.Track) []string {
+	uris := make([]string, len(tracks))
+	for i, t := range tracks {
+		uris[i] = "spotify:track:" + t.ID
+	}
+	return uris
+}
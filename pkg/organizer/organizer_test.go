@@ -0,0 +1,37 @@
+package organizer
+
+import (
+	"testing"
+
+	"github.com/ExploHash/organizify/pkg/spotify"
+)
+
+func TestTrackDifference(t *testing.T) {
+	a := []spotify.Track{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	b := []spotify.Track{{ID: "2"}}
+
+	diff := trackDifference(a, b)
+	if len(diff) != 2 || diff[0].ID != "1" || diff[1].ID != "3" {
+		t.Errorf("trackDifference(a, b) = %v, want tracks 1 and 3", diff)
+	}
+}
+
+func TestTrackDifferenceEmpty(t *testing.T) {
+	a := []spotify.Track{{ID: "1"}}
+	b := []spotify.Track{{ID: "1"}}
+
+	if diff := trackDifference(a, b); len(diff) != 0 {
+		t.Errorf("trackDifference(a, b) = %v, want empty", diff)
+	}
+}
+
+func TestTrackURIs(t *testing.T) {
+	tracks := []spotify.Track{{ID: "abc"}, {ID: "def"}}
+	uris := trackURIs(tracks)
+	want := []string{"spotify:track:abc", "spotify:track:def"}
+	for i := range want {
+		if uris[i] != want[i] {
+			t.Errorf("trackURIs(%v) = %v, want %v", tracks, uris, want)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Package organizer turns a YAML rule file into playlist assignments for
+// a user's liked songs, and diffs the result against live playlists so
+// the caller can apply the minimal set of changes.
+package organizer
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ExploHash/organizify/pkg/spotify"
+)
+
+// Rule matches liked songs against a set of conditions and routes
+// matches to a target playlist.
+type Rule struct {
+	Name string `yaml:"name"`
+
+	ArtistRegex string `yaml:"artist_regex"`
+	TrackRegex  string `yaml:"track_regex"`
+	AlbumRegex  string `yaml:"album_regex"`
+
+	AddedAfter  time.Time `yaml:"added_after"`
+	AddedBefore time.Time `yaml:"added_before"`
+
+	MinDurationMs int `yaml:"min_duration_ms"`
+	MaxDurationMs int `yaml:"max_duration_ms"`
+
+	// Tempo/energy/valence bounds are pointers, not plain float64s with a
+	// zero-value sentinel, because 0 is itself a legitimate bound for
+	// energy and valence (both range over [0,1]).
+	MinTempo   *float64 `yaml:"min_tempo"`
+	MaxTempo   *float64 `yaml:"max_tempo"`
+	MinEnergy  *float64 `yaml:"min_energy"`
+	MaxEnergy  *float64 `yaml:"max_energy"`
+	MinValence *float64 `yaml:"min_valence"`
+	MaxValence *float64 `yaml:"max_valence"`
+
+	// Playlist is the target playlist this rule routes matches into.
+	Playlist string `yaml:"playlist"`
+	// StopOnMatch stops evaluating later rules for a track once this
+	// one matches. Without it, a track can be routed into more than
+	// one playlist.
+	StopOnMatch bool `yaml:"stop_on_match"`
+
+	artistRegex *regexp.Regexp
+	trackRegex  *regexp.Regexp
+	albumRegex  *regexp.Regexp
+}
+
+// RuleSet is an ordered list of rules loaded from a YAML file. Rules are
+// evaluated in order for each track.
+type RuleSet struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// LoadRules reads and compiles a RuleSet from the YAML file at path.
+func LoadRules(path string) (*RuleSet, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	var set RuleSet
+	if err := yaml.Unmarshal(raw, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse rules file: %w", err)
+	}
+
+	for i := range set.Rules {
+		if err := set.Rules[i].compile(); err != nil {
+			return nil, fmt.Errorf("rule %q: %w", set.Rules[i].Name, err)
+		}
+	}
+
+	return &set, nil
+}
+
+func (r *Rule) compile() error {
+	var err error
+	if r.ArtistRegex != "" {
+		if r.artistRegex, err = regexp.Compile(r.ArtistRegex); err != nil {
+			return fmt.Errorf("invalid artist_regex: %w", err)
+		}
+	}
+	if r.TrackRegex != "" {
+		if r.trackRegex, err = regexp.Compile(r.TrackRegex); err != nil {
+			return fmt.Errorf("invalid track_regex: %w", err)
+		}
+	}
+	if r.AlbumRegex != "" {
+		if r.albumRegex, err = regexp.Compile(r.AlbumRegex); err != nil {
+			return fmt.Errorf("invalid album_regex: %w", err)
+		}
+	}
+	return nil
+}
+
+// needsAudioFeatures reports whether this rule has any tempo/energy/
+// valence bound, meaning Classify must fetch audio features for tracks
+// it's evaluated against.
+func (r *Rule) needsAudioFeatures() bool {
+	return r.MinTempo != nil || r.MaxTempo != nil ||
+		r.MinEnergy != nil || r.MaxEnergy != nil ||
+		r.MinValence != nil || r.MaxValence != nil
+}
+
+// Matches reports whether track, saved at addedAt, satisfies every
+// condition set on the rule. features may be nil if the rule doesn't
+// need audio features; Matches returns false for a rule that does need
+// them but wasn't given any.
+func (r *Rule) Matches(track spotify.Track, addedAt time.Time, features *spotify.AudioFeatures) bool {
+	if r.artistRegex != nil && !r.matchesAnyArtist(track) {
+		return false
+	}
+	if r.trackRegex != nil && !r.trackRegex.MatchString(track.Name) {
+		return false
+	}
+	if r.albumRegex != nil && !r.albumRegex.MatchString(track.Album.Name) {
+		return false
+	}
+	if !r.AddedAfter.IsZero() && addedAt.Before(r.AddedAfter) {
+		return false
+	}
+	if !r.AddedBefore.IsZero() && addedAt.After(r.AddedBefore) {
+		return false
+	}
+	if r.MinDurationMs > 0 && track.DurationMs < r.MinDurationMs {
+		return false
+	}
+	if r.MaxDurationMs > 0 && track.DurationMs > r.MaxDurationMs {
+		return false
+	}
+
+	if r.needsAudioFeatures() {
+		if features == nil {
+			return false
+		}
+		if r.MinTempo != nil && features.Tempo < *r.MinTempo {
+			return false
+		}
+		if r.MaxTempo != nil && features.Tempo > *r.MaxTempo {
+			return false
+		}
+		if r.MinEnergy != nil && features.Energy < *r.MinEnergy {
+			return false
+		}
+		if r.MaxEnergy != nil && features.Energy > *r.MaxEnergy {
+			return false
+		}
+		if r.MinValence != nil && features.Valence < *r.MinValence {
+			return false
+		}
+		if r.MaxValence != nil && features.Valence > *r.MaxValence {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Rule) matchesAnyArtist(track spotify.Track) bool {
+	for _, artist := range track.Artists {
+		if r.artistRegex.MatchString(artist.Name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package organizer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ExploHash/organizify/pkg/spotify"
+)
+
+func ptr(f float64) *float64 { return &f }
+
+func mustCompile(t *testing.T, r Rule) *Rule {
+	t.Helper()
+	if err := r.compile(); err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	return &r
+}
+
+func TestRuleMatchesArtistRegex(t *testing.T) {
+	r := mustCompile(t, Rule{ArtistRegex: "^Daft Punk$"})
+
+	track := spotify.Track{Artists: []struct {
+		Name string `json:"name"`
+	}{{Name: "Daft Punk"}}}
+	if !r.Matches(track, time.Time{}, nil) {
+		t.Error("expected match on exact artist name")
+	}
+
+	track.Artists[0].Name = "Daft Punk Tribute"
+	if r.Matches(track, time.Time{}, nil) {
+		t.Error("expected no match for a non-exact artist name")
+	}
+}
+
+func TestRuleMatchesAudioFeatureZeroBound(t *testing.T) {
+	// A zero bound (e.g. max_valence: 0) is a legitimate, meaningful
+	// filter, not "unset" — this is the case the float-sentinel bug used
+	// to get backwards.
+	r := mustCompile(t, Rule{MaxValence: ptr(0)})
+
+	if !r.needsAudioFeatures() {
+		t.Fatal("expected a zero MaxValence to still trigger an audio-features fetch")
+	}
+
+	track := spotify.Track{}
+	if r.Matches(track, time.Time{}, &spotify.AudioFeatures{Valence: 0.1}) {
+		t.Error("expected valence above the zero bound to be rejected")
+	}
+	if !r.Matches(track, time.Time{}, &spotify.AudioFeatures{Valence: 0}) {
+		t.Error("expected valence at the zero bound to match")
+	}
+}
+
+func TestRuleMatchesNeedsFeaturesButNoneGiven(t *testing.T) {
+	r := mustCompile(t, Rule{MinEnergy: ptr(0.5)})
+	if r.Matches(spotify.Track{}, time.Time{}, nil) {
+		t.Error("expected no match when features are required but not provided")
+	}
+}
+
+func TestRuleMatchesAddedAtBounds(t *testing.T) {
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := mustCompile(t, Rule{AddedAfter: after})
+
+	if r.Matches(spotify.Track{}, after.Add(-time.Hour), nil) {
+		t.Error("expected no match before AddedAfter")
+	}
+	if !r.Matches(spotify.Track{}, after.Add(time.Hour), nil) {
+		t.Error("expected match after AddedAfter")
+	}
+}
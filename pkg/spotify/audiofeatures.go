@@ -0,0 +1,53 @@
+package spotify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// maxAudioFeaturesPerRequest is Spotify's limit on how many track IDs can
+// be sent in a single /audio-features request.
+const maxAudioFeaturesPerRequest = 100
+
+// AudioFeatures holds Spotify's audio analysis for a single track.
+type AudioFeatures struct {
+	ID           string  `json:"id"`
+	Tempo        float64 `json:"tempo"`
+	Energy       float64 `json:"energy"`
+	Valence      float64 `json:"valence"`
+	Danceability float64 `json:"danceability"`
+}
+
+// GetAudioFeatures fetches audio features for trackIDs, batching to
+// Spotify's 100-ID-per-request limit.
+func (c *Client) GetAudioFeatures(trackIDs []string) ([]AudioFeatures, error) {
+	var all []AudioFeatures
+
+	for start := 0; start < len(trackIDs); start += maxAudioFeaturesPerRequest {
+		end := start + maxAudioFeaturesPerRequest
+		if end > len(trackIDs) {
+			end = len(trackIDs)
+		}
+
+		params := url.Values{}
+		params.Set("ids", strings.Join(trackIDs[start:end], ","))
+
+		body, err := c.makeRequest("GET", "/audio-features", params, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get audio features: %w", err)
+		}
+
+		var response struct {
+			AudioFeatures []AudioFeatures `json:"audio_features"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse audio features: %w", err)
+		}
+
+		all = append(all, response.AudioFeatures...)
+	}
+
+	return all, nil
+}
@@ -30,14 +30,16 @@ var (
 	state         string
 	codeVerifier  string
 	codeChallenge string
-	cachedToken   *oauth2.Token
 )
 
-// Scopes needed for playlists and liked songs
+// Scopes needed to read and organize playlists and liked songs
 var scopes = []string{
 	"playlist-read-private",
 	"playlist-read-collaborative",
+	"playlist-modify-public",
+	"playlist-modify-private",
 	"user-library-read",
+	"user-library-modify",
 }
 
 // generateRandomString generates a cryptographically secure random string
@@ -263,19 +265,25 @@ func completeAuthHandler(w http.ResponseWriter, r *http.Request) {
 	ch <- token
 }
 
-// Login authenticates the user with Spotify and returns access and refresh tokens
-// It uses in-memory token caching and automatic refresh
+// Login authenticates the user with Spotify and returns access and refresh
+// tokens. It reads and writes through the configured TokenStore (see
+// SetTokenStore), so a valid token survives process restarts, and
+// transparently refreshes an expired one via refreshToken.
 func Login() (accessToken string, refToken string, err error) {
-	// Check if we have a cached token in memory
-	if cachedToken != nil && isTokenValid(cachedToken) {
-		return cachedToken.AccessToken, cachedToken.RefreshToken, nil
-	}
+	// Check if we already have a persisted token.
+	if stored, loadErr := tokenStore.Load(); loadErr == nil {
+		if isTokenValid(stored) {
+			return stored.AccessToken, stored.RefreshToken, nil
+		}
 
-	// If token exists but is expired, try to refresh
-	if cachedToken != nil && !isTokenValid(cachedToken) {
-		newToken, err := refreshToken(cachedToken)
+		// Token exists but is expired; try to refresh it. Spotify
+		// sometimes rotates the refresh token, so persist whatever
+		// comes back.
+		newToken, err := refreshToken(stored)
 		if err == nil {
-			cachedToken = newToken
+			if err := tokenStore.Save(newToken); err != nil {
+				return "", "", fmt.Errorf("failed to persist refreshed token: %w", err)
+			}
 			return newToken.AccessToken, newToken.RefreshToken, nil
 		}
 	}
@@ -324,8 +332,10 @@ func Login() (accessToken string, refToken string, err error) {
 	defer cancel()
 	server.Shutdown(shutdownCtx)
 
-	// Cache token in memory
-	cachedToken = token
+	// Persist the token so future runs skip the browser flow.
+	if err := tokenStore.Save(token); err != nil {
+		return "", "", fmt.Errorf("failed to persist token: %w", err)
+	}
 
 	fmt.Println("✓ Authentication successful!")
 	return token.AccessToken, token.RefreshToken, nil
@@ -335,4 +345,26 @@ func Login() (accessToken string, refToken string, err error) {
 func GetAccessToken() (string, error) {
 	accessToken, _, err := Login()
 	return accessToken, err
+}
+
+// ForceRefresh refreshes the stored token unconditionally, bypassing the
+// isTokenValid check. Use this when an API response itself signals the
+// access token is no longer accepted (e.g. a 401) even though it hasn't
+// reached its recorded expiry yet.
+func ForceRefresh() (accessToken string, refToken string, err error) {
+	stored, err := tokenStore.Load()
+	if err != nil {
+		return "", "", fmt.Errorf("no token to refresh: %w", err)
+	}
+
+	newToken, err := refreshToken(stored)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := tokenStore.Save(newToken); err != nil {
+		return "", "", fmt.Errorf("failed to persist refreshed token: %w", err)
+	}
+
+	return newToken.AccessToken, newToken.RefreshToken, nil
 }
\ No newline at end of file
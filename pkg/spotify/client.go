@@ -1,82 +1,243 @@
 package spotify
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/errgroup"
 )
 
 const apiBaseURL = "https://api.spotify.com/v1"
 
+// Retry tuning for 429 and 5xx responses. baseBackoff doubles per attempt,
+// capped at maxBackoff, up to maxRetries attempts.
+const (
+	maxRetries  = 5
+	baseBackoff = 500 * time.Millisecond
+	maxBackoff  = 30 * time.Second
+)
+
+// defaultConcurrency is how many page requests a single GetAllPlaylists,
+// GetLikedSongs, or GetPlaylistTracks call, and how many playlists a
+// FetchAllPlaylistsWithTracks call, may have in flight at once unless
+// overridden via SetConcurrency.
+const defaultConcurrency = 6
+
 // Client wraps HTTP client with Spotify API helper methods
 type Client struct {
 	httpClient  *http.Client
-	accessToken string
+	tokenSource oauth2.TokenSource
 	ctx         context.Context
+	concurrency int
 }
 
-// NewClient creates a new Spotify client wrapper
+// NewClient creates a new Spotify client wrapper. It fetches an access
+// token eagerly so auth failures surface immediately rather than on the
+// first API call.
 func NewClient(ctx context.Context) (*Client, error) {
-	accessToken, err := GetAccessToken()
-	if err != nil {
+	ts := NewTokenSource()
+	if _, err := ts.Token(); err != nil {
 		return nil, fmt.Errorf("failed to get access token: %w", err)
 	}
 
 	return &Client{
 		httpClient:  &http.Client{},
-		accessToken: accessToken,
+		tokenSource: ts,
 		ctx:         ctx,
+		concurrency: defaultConcurrency,
 	}, nil
 }
 
-// makeRequest makes an authenticated request to the Spotify API
-func (c *Client) makeRequest(method, endpoint string, params url.Values) ([]byte, error) {
-	urlStr := apiBaseURL + endpoint
-	if params != nil {
-		urlStr += "?" + params.Encode()
+// SetConcurrency sets how many page/track requests the paginated fetch
+// methods may have in flight at once. n <= 0 is ignored.
+func (c *Client) SetConcurrency(n int) {
+	if n > 0 {
+		c.concurrency = n
 	}
+}
 
-	req, err := http.NewRequestWithContext(c.ctx, method, urlStr, nil)
-	if err != nil {
-		return nil, err
+// APIError represents a non-2xx response from the Spotify Web API.
+type APIError struct {
+	StatusCode int
+	Message    string
+	// SpotifyErr is Spotify's own error reason code when present, e.g.
+	// "PERMISSION_DENIED".
+	SpotifyErr string
+}
+
+func (e *APIError) Error() string {
+	if e.SpotifyErr != "" {
+		return fmt.Sprintf("spotify API error (status %d, %s): %s", e.StatusCode, e.SpotifyErr, e.Message)
+	}
+	return fmt.Sprintf("spotify API error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// parseAPIError builds an APIError from a non-2xx response body, falling
+// back to the raw body as the message if it isn't the usual Spotify
+// `{"error": {...}}` envelope.
+func parseAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode, Message: string(body)}
+
+	var payload struct {
+		Error struct {
+			Message string `json:"message"`
+			Reason  string `json:"reason"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Error.Message != "" {
+		apiErr.Message = payload.Error.Message
+		apiErr.SpotifyErr = payload.Error.Reason
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.accessToken)
-	req.Header.Set("Content-Type", "application/json")
+	return apiErr
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
+// backoffDelay returns a capped exponential backoff for retry attempt n
+// (0-indexed).
+func backoffDelay(attempt int) time.Duration {
+	d := baseBackoff * time.Duration(1<<attempt)
+	if d > maxBackoff {
+		return maxBackoff
+	}
+	return d
+}
+
+// retryAfterDelay honors a Retry-After header (seconds) if present and
+// sane, capped at maxBackoff, otherwise falls back to backoffDelay.
+func retryAfterDelay(header string, attempt int) time.Duration {
+	if header == "" {
+		return backoffDelay(attempt)
+	}
+	secs, err := strconv.Atoi(header)
+	if err != nil || secs < 0 {
+		return backoffDelay(attempt)
 	}
-	defer resp.Body.Close()
+	if d := time.Duration(secs) * time.Second; d < maxBackoff {
+		return d
+	}
+	return maxBackoff
+}
+
+// sleep waits for d, returning false early if the client's context is
+// canceled first.
+func (c *Client) sleep(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-c.ctx.Done():
+		return false
+	}
+}
 
-	body, err := io.ReadAll(resp.Body)
+// accessToken fetches a fresh access token from the token source for
+// every call, so a long-lived Client never sends a stale one.
+func (c *Client) accessToken() (string, error) {
+	tok, err := c.tokenSource.Token()
 	if err != nil {
-		return nil, err
+		return "", fmt.Errorf("failed to get access token: %w", err)
 	}
+	return tok.AccessToken, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed (status %d): %s", resp.StatusCode, string(body))
+// makeRequest makes an authenticated request to the Spotify API, sending
+// body (if non-nil) as the JSON request body. It retries 429 responses
+// (honoring Retry-After) and transient 5xx errors with capped exponential
+// backoff, and force-refreshes the token once and retries on a 401.
+func (c *Client) makeRequest(method, endpoint string, params url.Values, body []byte) ([]byte, error) {
+	urlStr := apiBaseURL + endpoint
+	if params != nil {
+		urlStr += "?" + params.Encode()
 	}
 
-	return body, nil
+	didForceRefresh := false
+
+	for attempt := 0; ; attempt++ {
+		accessToken, err := c.accessToken()
+		if err != nil {
+			return nil, err
+		}
+
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(body)
+		}
+
+		req, err := http.NewRequestWithContext(c.ctx, method, urlStr, bodyReader)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			return respBody, nil
+
+		case resp.StatusCode == http.StatusUnauthorized && !didForceRefresh:
+			didForceRefresh = true
+			fts, ok := c.tokenSource.(forcingTokenSource)
+			if !ok {
+				return nil, parseAPIError(resp.StatusCode, respBody)
+			}
+			if _, err := fts.ForceRefresh(); err != nil {
+				return nil, parseAPIError(resp.StatusCode, respBody)
+			}
+			continue
+
+		case resp.StatusCode == http.StatusTooManyRequests && attempt < maxRetries:
+			if !c.sleep(retryAfterDelay(resp.Header.Get("Retry-After"), attempt)) {
+				return nil, c.ctx.Err()
+			}
+			continue
+
+		case resp.StatusCode >= 500 && attempt < maxRetries:
+			if !c.sleep(backoffDelay(attempt)) {
+				return nil, c.ctx.Err()
+			}
+			continue
+
+		default:
+			return nil, parseAPIError(resp.StatusCode, respBody)
+		}
+	}
 }
 
 // Playlist represents a Spotify playlist
 type Playlist struct {
-	ID     string `json:"id"`
-	Name   string `json:"name"`
-	Tracks struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Tracks      struct {
 		Total int `json:"total"`
 	} `json:"tracks"`
 	Owner struct {
 		DisplayName string `json:"display_name"`
 	} `json:"owner"`
-	Public       bool   `json:"public"`
-	Collaborative bool  `json:"collaborative"`
+	Public        bool `json:"public"`
+	Collaborative bool `json:"collaborative"`
 }
 
 // Track represents a Spotify track
@@ -105,125 +266,206 @@ type User struct {
 	Email       string `json:"email"`
 }
 
-// GetAllPlaylists fetches all user playlists with automatic pagination
-func (c *Client) GetAllPlaylists() ([]Playlist, error) {
-	var allPlaylists []Playlist
-	limit := 50
-	offset := 0
+// paginate returns the offsets needed to cover total items limit at a
+// time, starting with offset 0.
+func paginate(total, limit int) []int {
+	if limit <= 0 || total <= 0 {
+		return []int{0}
+	}
+	offsets := make([]int, 0, (total+limit-1)/limit)
+	for offset := 0; offset < total; offset += limit {
+		offsets = append(offsets, offset)
+	}
+	return offsets
+}
 
-	for {
-		params := url.Values{}
-		params.Set("limit", fmt.Sprintf("%d", limit))
-		params.Set("offset", fmt.Sprintf("%d", offset))
+// fetchPages fetches page 0 to learn the total, then dispatches the
+// remaining pages across a bounded worker pool (c.concurrency), merging
+// results back in offset order via fetch.
+func fetchPages[T any](c *Client, limit int, fetch func(offset, limit int) ([]T, int, error)) ([]T, error) {
+	first, total, err := fetch(0, limit)
+	if err != nil {
+		return nil, err
+	}
 
-		body, err := c.makeRequest("GET", "/me/playlists", params)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get playlists: %w", err)
-		}
+	offsets := paginate(total, limit)
+	pages := make([][]T, len(offsets))
+	pages[0] = first
+
+	g := new(errgroup.Group)
+	g.SetLimit(c.concurrency)
+	for i := 1; i < len(offsets); i++ {
+		i, offset := i, offsets[i]
+		g.Go(func() error {
+			items, _, err := fetch(offset, limit)
+			if err != nil {
+				return err
+			}
+			pages[i] = items
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
 
-		var response struct {
-			Items []Playlist `json:"items"`
-			Total int        `json:"total"`
-			Next  *string    `json:"next"`
-		}
+	var all []T
+	for _, page := range pages {
+		all = append(all, page...)
+	}
+	return all, nil
+}
 
-		if err := json.Unmarshal(body, &response); err != nil {
-			return nil, fmt.Errorf("failed to parse playlists: %w", err)
-		}
+// GetAllPlaylists fetches all user playlists, paginating concurrently
+// across a bounded worker pool (see SetConcurrency).
+func (c *Client) GetAllPlaylists() ([]Playlist, error) {
+	playlists, err := fetchPages(c, 50, c.fetchPlaylistsPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playlists: %w", err)
+	}
+	return playlists, nil
+}
 
-		allPlaylists = append(allPlaylists, response.Items...)
+func (c *Client) fetchPlaylistsPage(offset, limit int) ([]Playlist, int, error) {
+	params := url.Values{}
+	params.Set("limit", fmt.Sprintf("%d", limit))
+	params.Set("offset", fmt.Sprintf("%d", offset))
 
-		if response.Next == nil || len(response.Items) < limit {
-			break
-		}
-		offset += limit
+	body, err := c.makeRequest("GET", "/me/playlists", params, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var response struct {
+		Items []Playlist `json:"items"`
+		Total int        `json:"total"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse playlists: %w", err)
 	}
 
-	return allPlaylists, nil
+	return response.Items, response.Total, nil
 }
 
-// GetLikedSongs fetches all user's liked songs (saved tracks) with automatic pagination
+// GetLikedSongs fetches all of the user's liked songs (saved tracks),
+// paginating concurrently across a bounded worker pool (see
+// SetConcurrency).
 func (c *Client) GetLikedSongs() ([]SavedTrack, error) {
-	var allTracks []SavedTrack
-	limit := 50
-	offset := 0
-
-	for {
-		params := url.Values{}
-		params.Set("limit", fmt.Sprintf("%d", limit))
-		params.Set("offset", fmt.Sprintf("%d", offset))
-
-		body, err := c.makeRequest("GET", "/me/tracks", params)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get liked songs: %w", err)
-		}
-
-		var response struct {
-			Items []SavedTrack `json:"items"`
-			Total int          `json:"total"`
-			Next  *string      `json:"next"`
-		}
+	tracks, err := fetchPages(c, 50, c.fetchLikedSongsPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get liked songs: %w", err)
+	}
+	return tracks, nil
+}
 
-		if err := json.Unmarshal(body, &response); err != nil {
-			return nil, fmt.Errorf("failed to parse liked songs: %w", err)
-		}
+func (c *Client) fetchLikedSongsPage(offset, limit int) ([]SavedTrack, int, error) {
+	params := url.Values{}
+	params.Set("limit", fmt.Sprintf("%d", limit))
+	params.Set("offset", fmt.Sprintf("%d", offset))
 
-		allTracks = append(allTracks, response.Items...)
+	body, err := c.makeRequest("GET", "/me/tracks", params, nil)
+	if err != nil {
+		return nil, 0, err
+	}
 
-		if response.Next == nil || len(response.Items) < limit {
-			break
-		}
-		offset += limit
+	var response struct {
+		Items []SavedTrack `json:"items"`
+		Total int          `json:"total"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse liked songs: %w", err)
 	}
 
-	return allTracks, nil
+	return response.Items, response.Total, nil
 }
 
-// GetPlaylistTracks fetches all tracks from a specific playlist with automatic pagination
+// GetPlaylistTracks fetches all tracks from a specific playlist,
+// paginating concurrently across a bounded worker pool (see
+// SetConcurrency).
 func (c *Client) GetPlaylistTracks(playlistID string) ([]Track, error) {
-	var allTracks []Track
-	limit := 100
-	offset := 0
+	tracks, err := fetchPages(c, 100, func(offset, limit int) ([]Track, int, error) {
+		return c.fetchPlaylistTracksPage(playlistID, offset, limit)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playlist tracks: %w", err)
+	}
+	return tracks, nil
+}
 
-	for {
-		params := url.Values{}
-		params.Set("limit", fmt.Sprintf("%d", limit))
-		params.Set("offset", fmt.Sprintf("%d", offset))
+func (c *Client) fetchPlaylistTracksPage(playlistID string, offset, limit int) ([]Track, int, error) {
+	params := url.Values{}
+	params.Set("limit", fmt.Sprintf("%d", limit))
+	params.Set("offset", fmt.Sprintf("%d", offset))
 
-		endpoint := fmt.Sprintf("/playlists/%s/tracks", playlistID)
-		body, err := c.makeRequest("GET", endpoint, params)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get playlist tracks: %w", err)
-		}
+	endpoint := fmt.Sprintf("/playlists/%s/tracks", playlistID)
+	body, err := c.makeRequest("GET", endpoint, params, nil)
+	if err != nil {
+		return nil, 0, err
+	}
 
-		var response struct {
-			Items []struct {
-				Track Track `json:"track"`
-			} `json:"items"`
-			Total int     `json:"total"`
-			Next  *string `json:"next"`
-		}
+	var response struct {
+		Items []struct {
+			Track Track `json:"track"`
+		} `json:"items"`
+		Total int `json:"total"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse playlist tracks: %w", err)
+	}
 
-		if err := json.Unmarshal(body, &response); err != nil {
-			return nil, fmt.Errorf("failed to parse playlist tracks: %w", err)
-		}
+	tracks := make([]Track, len(response.Items))
+	for i, item := range response.Items {
+		tracks[i] = item.Track
+	}
 
-		for _, item := range response.Items {
-			allTracks = append(allTracks, item.Track)
-		}
+	return tracks, response.Total, nil
+}
 
-		if response.Next == nil || len(response.Items) < limit {
-			break
-		}
-		offset += limit
+// PlaylistWithTracks pairs a playlist with its full track listing.
+type PlaylistWithTracks struct {
+	Playlist Playlist
+	Tracks   []Track
+}
+
+// FetchAllPlaylistsWithTracks fetches every playlist and then, with up to
+// c.concurrency playlists at a time, all of their tracks. Each playlist's
+// pages are fetched by GetPlaylistTracks with its own pool of the same
+// size; the pools are not shared, so up to c.concurrency squared requests
+// can be in flight at once. Canceling ctx stops playlists that have not
+// started yet; requests already running use the Client's own context.
+func (c *Client) FetchAllPlaylistsWithTracks(ctx context.Context) ([]PlaylistWithTracks, error) {
+	playlists, err := c.GetAllPlaylists()
+	if err != nil {
+		return nil, err
 	}
 
-	return allTracks, nil
+	results := make([]PlaylistWithTracks, len(playlists))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(c.concurrency)
+	for i, playlist := range playlists {
+		i, playlist := i, playlist
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			tracks, err := c.GetPlaylistTracks(playlist.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get tracks for playlist %q: %w", playlist.Name, err)
+			}
+			results[i] = PlaylistWithTracks{Playlist: playlist, Tracks: tracks}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
 }
 
 // GetCurrentUser fetches the current user's profile
 func (c *Client) GetCurrentUser() (*User, error) {
-	body, err := c.makeRequest("GET", "/me", nil)
+	body, err := c.makeRequest("GET", "/me", nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current user: %w", err)
 	}
@@ -236,6 +478,14 @@ func (c *Client) GetCurrentUser() (*User, error) {
 	return &user, nil
 }
 
+// ErrPlaylistNotFound is returned by GetPlaylistByName when the lookup
+// itself succeeded but no playlist with that name exists. Callers that
+// treat "not found" as "create it" must check for this specifically,
+// since GetPlaylistByName can also fail for transient reasons (rate
+// limiting, network errors, auth failures) that should instead be
+// propagated, not papered over with a duplicate playlist.
+var ErrPlaylistNotFound = errors.New("spotify: playlist not found")
+
 // GetPlaylistByName searches for a playlist by name in the user's playlists
 func (c *Client) GetPlaylistByName(name string) (*Playlist, error) {
 	playlists, err := c.GetAllPlaylists()
@@ -249,7 +499,7 @@ func (c *Client) GetPlaylistByName(name string) (*Playlist, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("playlist '%s' not found", name)
+	return nil, fmt.Errorf("%w: %q", ErrPlaylistNotFound, name)
 }
 
 // GetLikedSongsCount returns the total number of liked songs
@@ -257,7 +507,7 @@ func (c *Client) GetLikedSongsCount() (int, error) {
 	params := url.Values{}
 	params.Set("limit", "1")
 
-	body, err := c.makeRequest("GET", "/me/tracks", params)
+	body, err := c.makeRequest("GET", "/me/tracks", params, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get liked songs count: %w", err)
 	}
@@ -278,7 +528,7 @@ func (c *Client) GetPlaylistsCount() (int, error) {
 	params := url.Values{}
 	params.Set("limit", "1")
 
-	body, err := c.makeRequest("GET", "/me/playlists", params)
+	body, err := c.makeRequest("GET", "/me/playlists", params, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get playlists count: %w", err)
 	}
@@ -0,0 +1,47 @@
+package spotify
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, baseBackoff},
+		{1, 2 * baseBackoff},
+		{2, 4 * baseBackoff},
+		{10, maxBackoff}, // should be capped long before attempt 10
+	}
+
+	for _, tc := range cases {
+		if got := backoffDelay(tc.attempt); got != tc.want {
+			t.Errorf("backoffDelay(%d) = %v, want %v", tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	cases := []struct {
+		name    string
+		header  string
+		attempt int
+		want    time.Duration
+	}{
+		{"no header falls back to backoff", "", 1, backoffDelay(1)},
+		{"honors header", "2", 0, 2 * time.Second},
+		{"invalid header falls back to backoff", "not-a-number", 0, backoffDelay(0)},
+		{"negative header falls back to backoff", "-1", 0, backoffDelay(0)},
+		{"header longer than cap is capped", "3600", 0, maxBackoff},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := retryAfterDelay(tc.header, tc.attempt); got != tc.want {
+				t.Errorf("retryAfterDelay(%q, %d) = %v, want %v", tc.header, tc.attempt, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package spotify
+
+import "testing"
+
+func TestPaginate(t *testing.T) {
+	cases := []struct {
+		name  string
+		total int
+		limit int
+		want  []int
+	}{
+		{"zero total", 0, 50, []int{0}},
+		{"zero limit", 10, 0, []int{0}},
+		{"single page", 10, 50, []int{0}},
+		{"exact multiple", 100, 50, []int{0, 50}},
+		{"partial last page", 120, 50, []int{0, 50, 100}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := paginate(tc.total, tc.limit)
+			if len(got) != len(tc.want) {
+				t.Fatalf("paginate(%d, %d) = %v, want %v", tc.total, tc.limit, got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("paginate(%d, %d) = %v, want %v", tc.total, tc.limit, got, tc.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package portable
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ExploHash/organizify/pkg/spotify"
+)
+
+// WriteM3U writes tracks as an extended M3U playlist file at path, for
+// use with local music players.
+func WriteM3U(tracks []spotify.Track, path string) error {
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n")
+	for _, track := range tracks {
+		artists := artistNames(track)
+		fmt.Fprintf(&b, "#EXTINF:%d,%s - %s\n", track.DurationMs/1000, artists, track.Name)
+		fmt.Fprintf(&b, "%s - %s.mp3\n", artists, track.Name)
+	}
+
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write M3U file: %w", err)
+	}
+	return nil
+}
+
+func artistNames(track spotify.Track) string {
+	names := make([]string, len(track.Artists))
+	for i, artist := range track.Artists {
+		names[i] = artist.Name
+	}
+	return strings.Join(names, ", ")
+}
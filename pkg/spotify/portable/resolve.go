@@ -0,0 +1,189 @@
+package portable
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/ExploHash/organizify/pkg/spotify"
+)
+
+// searchCandidates is how many search results are considered per track
+// when resolving a snapshot track back to a Spotify URI.
+const searchCandidates = 5
+
+var remasterTagPattern = regexp.MustCompile(`(?i)\s*\([^)]*remaster[^)]*\)`)
+
+// normalize lowercases s, strips parenthetical tags that mention
+// "remaster" (e.g. "(2011 Remastered Version)"; other tags such as
+// "(Remix)" are kept), and unicode-folds it (e.g. "Café" -> "cafe") so fuzzy matching tolerates
+// the cosmetic differences between two libraries' metadata.
+func normalize(s string) string {
+	s = remasterTagPattern.ReplaceAllString(s, "")
+	s = strings.ToLower(s)
+
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if unicode.Is(unicode.Mn, r) {
+			continue // drop the combining marks NFD split diacritics into
+		}
+		b.WriteRune(r)
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// UnresolvedTrack records a snapshot track that couldn't be confidently
+// matched to anything in the destination account's search results.
+type UnresolvedTrack struct {
+	Track  spotify.Track
+	Reason string
+}
+
+// Restore resolves every track in snap back to a Spotify URI via search
+// and adds each playlist's tracks to the playlist of the same name in the
+// account behind c, creating it only if no such playlist exists, then
+// saves the snapshot's liked songs.
+// Tracks that can't be confidently matched are skipped rather than
+// aborting the whole restore, and are returned as unresolved so the
+// caller can report them (see WriteUnresolvedReport).
+func Restore(c *spotify.Client, userID string, snap *Snapshot) (unresolved []UnresolvedTrack, err error) {
+	for _, ps := range snap.Playlists {
+		playlist, playlistErr := c.GetPlaylistByName(ps.Name)
+		if playlistErr != nil {
+			if !errors.Is(playlistErr, spotify.ErrPlaylistNotFound) {
+				return unresolved, fmt.Errorf("failed to look up playlist %q: %w", ps.Name, playlistErr)
+			}
+			playlist, err = c.CreatePlaylist(userID, ps.Name, ps.Description, ps.Public)
+			if err != nil {
+				return unresolved, fmt.Errorf("failed to create playlist %q: %w", ps.Name, err)
+			}
+		}
+
+		var uris []string
+		for _, track := range ps.Tracks {
+			uri, ok := resolveTrack(c, track)
+			if !ok {
+				unresolved = append(unresolved, UnresolvedTrack{Track: track, Reason: "no confident search match"})
+				continue
+			}
+			uris = append(uris, uri)
+		}
+
+		if len(uris) > 0 {
+			if _, err := c.AddTracksToPlaylist(playlist.ID, uris); err != nil {
+				return unresolved, fmt.Errorf("failed to add tracks to playlist %q: %w", ps.Name, err)
+			}
+		}
+	}
+
+	var likedIDs []string
+	for _, saved := range snap.LikedSongs {
+		uri, ok := resolveTrack(c, saved.Track)
+		if !ok {
+			unresolved = append(unresolved, UnresolvedTrack{Track: saved.Track, Reason: "no confident search match"})
+			continue
+		}
+		likedIDs = append(likedIDs, strings.TrimPrefix(uri, "spotify:track:"))
+	}
+	if len(likedIDs) > 0 {
+		if err := c.SaveTracks(likedIDs); err != nil {
+			return unresolved, fmt.Errorf("failed to save liked songs: %w", err)
+		}
+	}
+
+	return unresolved, nil
+}
+
+// matchThreshold is the minimum token-set similarity (see tokenSetRatio)
+// a search candidate must clear against the wanted "artist title" to be
+// accepted as a match. It's well below 1.0 so things like a missing
+// "feat." credit or a "- Live" suffix that normalize doesn't strip don't
+// sink an otherwise-correct match.
+const matchThreshold = 0.6
+
+// resolveTrack searches for track and returns the URI of the best-scoring
+// candidate whose normalized "artist title" is a close enough fuzzy match,
+// per tokenSetRatio.
+func resolveTrack(c *spotify.Client, track spotify.Track) (string, bool) {
+	query := fmt.Sprintf("track:%s artist:%s", track.Name, primaryArtist(track))
+	candidates, err := c.SearchTracks(query, searchCandidates)
+	if err != nil || len(candidates) == 0 {
+		return "", false
+	}
+
+	want := normalize(primaryArtist(track) + " " + track.Name)
+
+	var bestID string
+	var bestScore float64
+	for _, candidate := range candidates {
+		got := normalize(primaryArtist(candidate) + " " + candidate.Name)
+		if score := tokenSetRatio(want, got); score > bestScore {
+			bestScore = score
+			bestID = candidate.ID
+		}
+	}
+
+	if bestScore < matchThreshold {
+		return "", false
+	}
+	return "spotify:track:" + bestID, true
+}
+
+// tokenSetRatio scores the similarity of two normalized, space-separated
+// strings as the fraction of their combined tokens that appear in both,
+// so word order and minor additions (a stray "feat." credit, a duplicated
+// word) don't prevent a match the way exact equality would.
+func tokenSetRatio(a, b string) float64 {
+	tokensA := strings.Fields(a)
+	tokensB := strings.Fields(b)
+	if len(tokensA) == 0 || len(tokensB) == 0 {
+		return 0
+	}
+
+	setB := make(map[string]bool, len(tokensB))
+	for _, t := range tokensB {
+		setB[t] = true
+	}
+
+	shared := 0
+	seen := make(map[string]bool, len(tokensA))
+	for _, t := range tokensA {
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		if setB[t] {
+			shared++
+		}
+	}
+
+	return 2 * float64(shared) / float64(len(tokensA)+len(tokensB))
+}
+
+func primaryArtist(track spotify.Track) string {
+	if len(track.Artists) == 0 {
+		return ""
+	}
+	return track.Artists[0].Name
+}
+
+// WriteUnresolvedReport writes a human-readable list of unresolved
+// tracks to path, so the user can review and fix misses by hand.
+func WriteUnresolvedReport(unresolved []UnresolvedTrack, path string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d unresolved track(s)\n\n", len(unresolved))
+	for _, u := range unresolved {
+		fmt.Fprintf(&b, "- %s - %s (%s)\n", primaryArtist(u.Track), u.Track.Name, u.Reason)
+	}
+
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write unresolved report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package portable
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"lowercases", "Café Del Mar", "cafe del mar"},
+		{"strips remaster tag", "Thriller (2003 Remaster)", "thriller"},
+		{"strips remastered tag regardless of case", "Yesterday (Remastered Version)", "yesterday"},
+		{"collapses whitespace left by a stripped tag", "Take Five (Remaster)  - Extended", "take five - extended"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := normalize(tc.in); got != tc.want {
+				t.Errorf("normalize(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTokenSetRatio(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{"identical strings score 1", "daft punk one more time", "daft punk one more time", 1},
+		{"disjoint strings score 0", "daft punk one more time", "totally different song", 0},
+		{"extra feat credit still scores high", "one more time daft punk", "one more time daft punk feat someone", 5.0 / 6.0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tokenSetRatio(tc.a, tc.b); got != tc.want {
+				t.Errorf("tokenSetRatio(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTokenSetRatioAboveThreshold(t *testing.T) {
+	want := normalize("daft punk one more time")
+	got := normalize("daft punk one more time - live")
+	if score := tokenSetRatio(want, got); score < matchThreshold {
+		t.Errorf("tokenSetRatio(%q, %q) = %v, want >= %v", want, got, score, matchThreshold)
+	}
+}
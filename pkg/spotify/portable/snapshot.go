@@ -0,0 +1,96 @@
+// Package portable serializes a Spotify library to a versioned JSON
+// snapshot that can be restored into another account or exported as M3U
+// playlists for local music players.
+package portable
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ExploHash/organizify/pkg/spotify"
+)
+
+// SnapshotVersion is bumped whenever the Snapshot layout changes in a way
+// that breaks older readers.
+const SnapshotVersion = 1
+
+// Snapshot is a portable capture of a user's library: every playlist with
+// its tracks, plus liked songs.
+type Snapshot struct {
+	Version    int                `json:"version"`
+	CreatedAt  time.Time          `json:"created_at"`
+	Playlists  []PlaylistSnapshot `json:"playlists"`
+	LikedSongs []spotify.SavedTrack `json:"liked_songs"`
+}
+
+// PlaylistSnapshot captures one playlist and its tracks at export time.
+type PlaylistSnapshot struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Public      bool            `json:"public"`
+	Tracks      []spotify.Track `json:"tracks"`
+}
+
+// Capture builds a Snapshot from a live client: every playlist with its
+// tracks, plus liked songs.
+func Capture(c *spotify.Client) (*Snapshot, error) {
+	playlists, err := c.GetAllPlaylists()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playlists: %w", err)
+	}
+
+	snap := &Snapshot{Version: SnapshotVersion, CreatedAt: time.Now()}
+
+	for _, playlist := range playlists {
+		tracks, err := c.GetPlaylistTracks(playlist.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tracks for playlist %q: %w", playlist.Name, err)
+		}
+		snap.Playlists = append(snap.Playlists, PlaylistSnapshot{
+			Name:        playlist.Name,
+			Description: playlist.Description,
+			Public:      playlist.Public,
+			Tracks:      tracks,
+		})
+	}
+
+	liked, err := c.GetLikedSongs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get liked songs: %w", err)
+	}
+	snap.LikedSongs = liked
+
+	return snap, nil
+}
+
+// Save writes snap to path as indented JSON.
+func Save(snap *Snapshot, path string) error {
+	raw, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize snapshot: %w", err)
+	}
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load reads a Snapshot previously written by Save.
+func Load(path string) (*Snapshot, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(raw, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	if snap.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d (expected %d)", snap.Version, SnapshotVersion)
+	}
+
+	return &snap, nil
+}
@@ -0,0 +1,32 @@
+package spotify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// SearchTracks searches the Spotify catalog for tracks matching query,
+// returning up to limit results.
+func (c *Client) SearchTracks(query string, limit int) ([]Track, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("type", "track")
+	params.Set("limit", fmt.Sprintf("%d", limit))
+
+	body, err := c.makeRequest("GET", "/search", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tracks: %w", err)
+	}
+
+	var response struct {
+		Tracks struct {
+			Items []Track `json:"items"`
+		} `json:"tracks"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse search results: %w", err)
+	}
+
+	return response.Tracks.Items, nil
+}
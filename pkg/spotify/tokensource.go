@@ -0,0 +1,54 @@
+package spotify
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// TokenSource adapts organizify's Login/refresh flow (which itself reads
+// and writes through the configured TokenStore) to the oauth2.TokenSource
+// interface. All calls are serialized by a mutex so concurrent goroutines
+// sharing a Client can't stampede the token endpoint.
+type TokenSource struct {
+	mu sync.Mutex
+}
+
+// NewTokenSource creates a TokenSource backed by Login.
+func NewTokenSource() *TokenSource {
+	return &TokenSource{}
+}
+
+// Token returns a valid access token, logging in or refreshing as needed.
+func (s *TokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accessToken, refToken, err := Login()
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{AccessToken: accessToken, RefreshToken: refToken, TokenType: "Bearer"}, nil
+}
+
+// ForceRefresh refreshes the token unconditionally, bypassing the
+// isTokenValid check. Client falls back to this after the API itself
+// reports 401, since Spotify can revoke a token before it expires.
+func (s *TokenSource) ForceRefresh() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accessToken, refToken, err := ForceRefresh()
+	if err != nil {
+		return nil, fmt.Errorf("failed to force-refresh token: %w", err)
+	}
+	return &oauth2.Token{AccessToken: accessToken, RefreshToken: refToken, TokenType: "Bearer"}, nil
+}
+
+// forcingTokenSource is implemented by TokenSource; Client type-asserts
+// to it so it can force a refresh after a 401 without caring whether a
+// caller has swapped in some other oauth2.TokenSource (e.g. in tests).
+type forcingTokenSource interface {
+	ForceRefresh() (*oauth2.Token, error)
+}
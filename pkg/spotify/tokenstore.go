@@ -0,0 +1,226 @@
+package spotify
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/oauth2"
+)
+
+const (
+	keyringService = "organizify"
+	keyringUser    = "spotify-refresh-token"
+)
+
+// ErrNoToken is returned by TokenStore.Load when no token has been
+// persisted yet.
+var ErrNoToken = errors.New("spotify: no token in store")
+
+// TokenStore persists and retrieves the OAuth token used to authenticate
+// with Spotify, so Login does not have to re-run the browser flow on
+// every process restart.
+type TokenStore interface {
+	Load() (*oauth2.Token, error)
+	Save(token *oauth2.Token) error
+}
+
+// tokenStore is the store Login and GetAccessToken read/write through.
+// It defaults to the OS keyring; override it with SetTokenStore.
+var tokenStore TokenStore = NewKeyringTokenStore()
+
+// SetTokenStore overrides the default token store used by Login and
+// GetAccessToken. Call it before the first Login, e.g. to swap in a
+// file-backed or in-memory store for tests.
+func SetTokenStore(s TokenStore) {
+	tokenStore = s
+}
+
+// KeyringTokenStore persists the token in the OS keyring (Keychain,
+// Secret Service, Credential Manager, ...) via go-keyring. It's the
+// default store.
+type KeyringTokenStore struct {
+	service string
+	user    string
+}
+
+// NewKeyringTokenStore creates a KeyringTokenStore using organizify's
+// default service/user identifiers.
+func NewKeyringTokenStore() *KeyringTokenStore {
+	return &KeyringTokenStore{service: keyringService, user: keyringUser}
+}
+
+func (s *KeyringTokenStore) Load() (*oauth2.Token, error) {
+	raw, err := keyring.Get(s.service, s.user)
+	if err != nil {
+		if errors.Is(err, keyring.ErrNotFound) {
+			return nil, ErrNoToken
+		}
+		return nil, fmt.Errorf("failed to read token from keyring: %w", err)
+	}
+
+	var token oauth2.Token
+	if err := json.Unmarshal([]byte(raw), &token); err != nil {
+		return nil, fmt.Errorf("failed to parse stored token: %w", err)
+	}
+	return &token, nil
+}
+
+func (s *KeyringTokenStore) Save(token *oauth2.Token) error {
+	raw, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to serialize token: %w", err)
+	}
+	if err := keyring.Set(s.service, s.user, string(raw)); err != nil {
+		return fmt.Errorf("failed to write token to keyring: %w", err)
+	}
+	return nil
+}
+
+// scryptN, scryptR, scryptP, scryptKeyLen are the scrypt parameters used
+// to derive the AES-256 key for FileTokenStore from a user passphrase.
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	scryptSaltLen = 16
+)
+
+// encryptedToken is the on-disk layout for a FileTokenStore entry: the
+// scrypt salt plus an AES-GCM sealed token, all base64-friendly via JSON.
+type encryptedToken struct {
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// FileTokenStore persists the token to a file, encrypting the token JSON
+// with AES-GCM using a key derived from a user-supplied passphrase via
+// scrypt. A fresh salt is generated on every Save.
+type FileTokenStore struct {
+	path       string
+	passphrase []byte
+}
+
+// NewFileTokenStore creates a FileTokenStore backed by the file at path,
+// encrypted with a key derived from passphrase.
+func NewFileTokenStore(path, passphrase string) *FileTokenStore {
+	return &FileTokenStore{path: path, passphrase: []byte(passphrase)}
+}
+
+func (s *FileTokenStore) Load() (*oauth2.Token, error) {
+	raw, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoToken
+		}
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	var enc encryptedToken
+	if err := json.Unmarshal(raw, &enc); err != nil {
+		return nil, fmt.Errorf("failed to parse token file: %w", err)
+	}
+
+	gcm, err := s.gcmForSalt(enc.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, enc.Nonce, enc.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt token (wrong passphrase?): %w", err)
+	}
+
+	var token oauth2.Token
+	if err := json.Unmarshal(plaintext, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse decrypted token: %w", err)
+	}
+	return &token, nil
+}
+
+func (s *FileTokenStore) Save(token *oauth2.Token) error {
+	plaintext, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to serialize token: %w", err)
+	}
+
+	salt := make([]byte, scryptSaltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := s.gcmForSalt(salt)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
+
+	raw, err := json.Marshal(encryptedToken{Salt: salt, Nonce: nonce, Ciphertext: ciphertext})
+	if err != nil {
+		return fmt.Errorf("failed to serialize token file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create token directory: %w", err)
+	}
+	if err := os.WriteFile(s.path, raw, 0o600); err != nil {
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+	return nil
+}
+
+// gcmForSalt derives the AES key for salt and wraps it in a GCM AEAD.
+func (s *FileTokenStore) gcmForSalt(salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(s.passphrase, salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return gcm, nil
+}
+
+// MemoryTokenStore keeps the token in memory only. Useful for tests and
+// for callers that want to opt out of persistence entirely.
+type MemoryTokenStore struct {
+	token *oauth2.Token
+}
+
+// NewMemoryTokenStore creates an empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{}
+}
+
+func (s *MemoryTokenStore) Load() (*oauth2.Token, error) {
+	if s.token == nil {
+		return nil, ErrNoToken
+	}
+	return s.token, nil
+}
+
+func (s *MemoryTokenStore) Save(token *oauth2.Token) error {
+	s.token = token
+	return nil
+}
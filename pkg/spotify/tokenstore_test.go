@@ -0,0 +1,126 @@
+package spotify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestFileTokenStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	store := NewFileTokenStore(path, "correct horse battery staple")
+
+	want := &oauth2.Token{
+		AccessToken:  "access-123",
+		RefreshToken: "refresh-456",
+		Expiry:       time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+	if err := store.Save(want); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got.AccessToken != want.AccessToken || got.RefreshToken != want.RefreshToken || !got.Expiry.Equal(want.Expiry) {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFileTokenStoreWrongPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+
+	if err := NewFileTokenStore(path, "right passphrase").Save(&oauth2.Token{AccessToken: "secret"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	if _, err := NewFileTokenStore(path, "wrong passphrase").Load(); err == nil {
+		t.Error("Load with wrong passphrase succeeded, want decryption failure")
+	}
+}
+
+func TestFileTokenStoreLoadMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "does-not-exist.json")
+
+	_, err := NewFileTokenStore(path, "whatever").Load()
+	if !errors.Is(err, ErrNoToken) {
+		t.Errorf("Load() error = %v, want ErrNoToken", err)
+	}
+}
+
+func TestFileTokenStoreFreshSaltPerSave(t *testing.T) {
+	// Each Save should generate a new salt/nonce, so the store never
+	// reuses a nonce under the same key.
+	path := filepath.Join(t.TempDir(), "token.json")
+	store := NewFileTokenStore(path, "pass")
+
+	if err := store.Save(&oauth2.Token{AccessToken: "one"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	firstRaw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	first, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if err := store.Save(&oauth2.Token{AccessToken: "two"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	secondRaw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	second, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if first.AccessToken == second.AccessToken {
+		t.Fatalf("expected distinct tokens across saves, got %q both times", first.AccessToken)
+	}
+
+	var firstEnc, secondEnc encryptedToken
+	if err := json.Unmarshal(firstRaw, &firstEnc); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if err := json.Unmarshal(secondRaw, &secondEnc); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if bytes.Equal(firstEnc.Salt, secondEnc.Salt) {
+		t.Errorf("expected a fresh salt per Save, got the same salt twice")
+	}
+	if bytes.Equal(firstEnc.Nonce, secondEnc.Nonce) {
+		t.Errorf("expected a fresh nonce per Save, got the same nonce twice")
+	}
+}
+
+func TestMemoryTokenStore(t *testing.T) {
+	store := NewMemoryTokenStore()
+
+	if _, err := store.Load(); !errors.Is(err, ErrNoToken) {
+		t.Errorf("Load() on empty store error = %v, want ErrNoToken", err)
+	}
+
+	want := &oauth2.Token{AccessToken: "abc"}
+	if err := store.Save(want); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got.AccessToken != want.AccessToken {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+}
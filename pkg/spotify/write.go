@@ -0,0 +1,224 @@
+package spotify
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// maxURIsPerRequest is Spotify's limit on how many track URIs can be sent
+// in a single playlist add/remove/reorder request.
+const maxURIsPerRequest = 100
+
+// PlaylistSnapshot identifies a specific revision of a playlist's track
+// list. Pass it to RemoveTracksFromPlaylist to guard against racing with
+// another concurrent edit.
+type PlaylistSnapshot struct {
+	ID string `json:"snapshot_id"`
+}
+
+// CreatePlaylist creates a new playlist for userID.
+func (c *Client) CreatePlaylist(userID, name, description string, public bool) (*Playlist, error) {
+	payload, err := json.Marshal(struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Public      bool   `json:"public"`
+	}{Name: name, Description: description, Public: public})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize playlist: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("/users/%s/playlists", userID)
+	body, err := c.makeRequest("POST", endpoint, nil, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create playlist: %w", err)
+	}
+
+	var playlist Playlist
+	if err := json.Unmarshal(body, &playlist); err != nil {
+		return nil, fmt.Errorf("failed to parse created playlist: %w", err)
+	}
+	return &playlist, nil
+}
+
+// AddTracksToPlaylist adds trackURIs to playlistID, batching to Spotify's
+// 100-URI-per-request limit, and returns the snapshot ID of the last
+// batch applied.
+func (c *Client) AddTracksToPlaylist(playlistID string, trackURIs []string) (snapshotID string, err error) {
+	endpoint := fmt.Sprintf("/playlists/%s/tracks", playlistID)
+
+	for start := 0; start < len(trackURIs); start += maxURIsPerRequest {
+		end := start + maxURIsPerRequest
+		if end > len(trackURIs) {
+			end = len(trackURIs)
+		}
+
+		payload, err := json.Marshal(struct {
+			URIs []string `json:"uris"`
+		}{URIs: trackURIs[start:end]})
+		if err != nil {
+			return "", fmt.Errorf("failed to serialize tracks: %w", err)
+		}
+
+		body, err := c.makeRequest("POST", endpoint, nil, payload)
+		if err != nil {
+			return "", fmt.Errorf("failed to add tracks to playlist: %w", err)
+		}
+
+		var response PlaylistSnapshot
+		if err := json.Unmarshal(body, &response); err != nil {
+			return "", fmt.Errorf("failed to parse snapshot: %w", err)
+		}
+		snapshotID = response.ID
+	}
+
+	return snapshotID, nil
+}
+
+// RemoveTracksFromPlaylist removes trackURIs from playlistID, batching to
+// Spotify's 100-URI-per-request limit. If snapshot is non-empty, it's sent
+// with the first batch so the removal is rejected if the playlist has
+// changed since the caller last read it.
+func (c *Client) RemoveTracksFromPlaylist(playlistID string, trackURIs []string, snapshot PlaylistSnapshot) (snapshotID string, err error) {
+	endpoint := fmt.Sprintf("/playlists/%s/tracks", playlistID)
+
+	for start := 0; start < len(trackURIs); start += maxURIsPerRequest {
+		end := start + maxURIsPerRequest
+		if end > len(trackURIs) {
+			end = len(trackURIs)
+		}
+
+		tracks := make([]struct {
+			URI string `json:"uri"`
+		}, end-start)
+		for i, uri := range trackURIs[start:end] {
+			tracks[i].URI = uri
+		}
+
+		payloadStruct := struct {
+			Tracks []struct {
+				URI string `json:"uri"`
+			} `json:"tracks"`
+			SnapshotID string `json:"snapshot_id,omitempty"`
+		}{Tracks: tracks}
+		if start == 0 {
+			payloadStruct.SnapshotID = snapshot.ID
+		}
+
+		payload, err := json.Marshal(payloadStruct)
+		if err != nil {
+			return "", fmt.Errorf("failed to serialize tracks: %w", err)
+		}
+
+		body, err := c.makeRequest("DELETE", endpoint, nil, payload)
+		if err != nil {
+			return "", fmt.Errorf("failed to remove tracks from playlist: %w", err)
+		}
+
+		var response PlaylistSnapshot
+		if err := json.Unmarshal(body, &response); err != nil {
+			return "", fmt.Errorf("failed to parse snapshot: %w", err)
+		}
+		snapshotID = response.ID
+	}
+
+	return snapshotID, nil
+}
+
+// ReorderPlaylistTracks moves the length tracks starting at rangeStart to
+// insertBefore, returning the resulting snapshot ID. snapshot, if
+// non-empty, guards against a concurrent edit.
+func (c *Client) ReorderPlaylistTracks(playlistID string, rangeStart, length, insertBefore int, snapshot PlaylistSnapshot) (snapshotID string, err error) {
+	payload, err := json.Marshal(struct {
+		RangeStart   int    `json:"range_start"`
+		RangeLength  int    `json:"range_length"`
+		InsertBefore int    `json:"insert_before"`
+		SnapshotID   string `json:"snapshot_id,omitempty"`
+	}{RangeStart: rangeStart, RangeLength: length, InsertBefore: insertBefore, SnapshotID: snapshot.ID})
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize reorder request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("/playlists/%s/tracks", playlistID)
+	body, err := c.makeRequest("PUT", endpoint, nil, payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to reorder playlist tracks: %w", err)
+	}
+
+	var response PlaylistSnapshot
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	return response.ID, nil
+}
+
+// SaveTracks adds trackIDs to the current user's liked songs, batching to
+// Spotify's 100-ID-per-request limit.
+func (c *Client) SaveTracks(trackIDs []string) error {
+	for start := 0; start < len(trackIDs); start += maxURIsPerRequest {
+		end := start + maxURIsPerRequest
+		if end > len(trackIDs) {
+			end = len(trackIDs)
+		}
+
+		payload, err := json.Marshal(struct {
+			IDs []string `json:"ids"`
+		}{IDs: trackIDs[start:end]})
+		if err != nil {
+			return fmt.Errorf("failed to serialize tracks: %w", err)
+		}
+
+		if _, err := c.makeRequest("PUT", "/me/tracks", nil, payload); err != nil {
+			return fmt.Errorf("failed to save tracks: %w", err)
+		}
+	}
+	return nil
+}
+
+// RemoveSavedTracks removes trackIDs from the current user's liked songs,
+// batching to Spotify's 100-ID-per-request limit.
+func (c *Client) RemoveSavedTracks(trackIDs []string) error {
+	for start := 0; start < len(trackIDs); start += maxURIsPerRequest {
+		end := start + maxURIsPerRequest
+		if end > len(trackIDs) {
+			end = len(trackIDs)
+		}
+
+		payload, err := json.Marshal(struct {
+			IDs []string `json:"ids"`
+		}{IDs: trackIDs[start:end]})
+		if err != nil {
+			return fmt.Errorf("failed to serialize tracks: %w", err)
+		}
+
+		if _, err := c.makeRequest("DELETE", "/me/tracks", nil, payload); err != nil {
+			return fmt.Errorf("failed to remove saved tracks: %w", err)
+		}
+	}
+	return nil
+}
+
+// FollowPlaylist adds playlistID to the current user's library. public
+// controls whether the playlist shows up in the user's public profile.
+func (c *Client) FollowPlaylist(playlistID string, public bool) error {
+	payload, err := json.Marshal(struct {
+		Public bool `json:"public"`
+	}{Public: public})
+	if err != nil {
+		return fmt.Errorf("failed to serialize follow request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("/playlists/%s/followers", playlistID)
+	if _, err := c.makeRequest("PUT", endpoint, nil, payload); err != nil {
+		return fmt.Errorf("failed to follow playlist: %w", err)
+	}
+	return nil
+}
+
+// UnfollowPlaylist removes playlistID from the current user's library.
+func (c *Client) UnfollowPlaylist(playlistID string) error {
+	endpoint := fmt.Sprintf("/playlists/%s/followers", playlistID)
+	if _, err := c.makeRequest("DELETE", endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to unfollow playlist: %w", err)
+	}
+	return nil
+}